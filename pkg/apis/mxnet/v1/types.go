@@ -0,0 +1,222 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package v1 contains the API types for the MXJob custom resource.
+package v1
+
+import (
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MXJobNameLabel is the label set on every pod and service created for an
+// MXJob, with the MXJob's name as its value. It is the single source of
+// truth for that label key: both the pod/service creation code and anything
+// that needs to find an MXJob's resources again (e.g. suspending it) must
+// use this constant rather than a private copy of the string.
+const MXJobNameLabel = "mxnet-job-name"
+
+// MXReplicaType is the type for MXReplica. Can be one of: Scheduler, Server, Worker, Tuner or Launcher.
+type MXReplicaType string
+
+const (
+	// MXReplicaTypeScheduler is the type of Scheduler of distributed MXNet.
+	MXReplicaTypeScheduler MXReplicaType = "Scheduler"
+
+	// MXReplicaTypeServer is the type of Server of distributed MXNet.
+	MXReplicaTypeServer MXReplicaType = "Server"
+
+	// MXReplicaTypeWorker is the type for training worker of distributed MXNet.
+	MXReplicaTypeWorker MXReplicaType = "Worker"
+
+	// MXReplicaTypeTuner is the type for auto tuning worker of distributed MXNet.
+	MXReplicaTypeTuner MXReplicaType = "Tuner"
+
+	// MXReplicaTypeLauncher is the type for the launcher of a single-controller
+	// MXNet job (e.g. Horovod-on-MXNet/MPI), analogous to MPIJob's Launcher.
+	// When present, the launcher pod is the sole source of truth for job
+	// completion instead of the aggregate worker replica counts.
+	MXReplicaTypeLauncher MXReplicaType = "Launcher"
+)
+
+// MXJobConditionType defines all kinds of types of MXJobStatus.
+type MXJobConditionType string
+
+const (
+	// MXJobCreated means the mxjob has been accepted by the system.
+	MXJobCreated MXJobConditionType = "Created"
+
+	// MXJobRunning means all sub-resources (e.g. services/pods) of this mxjob
+	// have been successfully scheduled and launched.
+	MXJobRunning MXJobConditionType = "Running"
+
+	// MXJobRestarting means one or more sub-resources (e.g. services/pods) of this mxjob
+	// reached phase failed but maybe restarted according to it's restart policy.
+	MXJobRestarting MXJobConditionType = "Restarting"
+
+	// MXJobSucceeded means all sub-resources (e.g. services/pods) of this mxjob
+	// reached phase succeeded.
+	MXJobSucceeded MXJobConditionType = "Succeeded"
+
+	// MXJobFailed means one or more sub-resources (e.g. services/pods) of this mxjob
+	// reached phase failed with no restarting.
+	MXJobFailed MXJobConditionType = "Failed"
+
+	// MXJobSuspended means the mxjob has been suspended, i.e. spec.Suspend is set
+	// to true and all of its active pods/services have been deleted.
+	MXJobSuspended MXJobConditionType = "Suspended"
+
+	// MXJobFinished is a bookkeeping condition written exactly once, the first
+	// time an MXJob reaches a terminal state (Succeeded or Failed). Unlike the
+	// terminal conditions themselves it is not meant to be read as the job's
+	// phase; it exists so that finalizer-driven cleanup (e.g. Kueue's
+	// jobframework) has a single, idempotent signal to watch for. See
+	// MXJob.Finished.
+	MXJobFinished MXJobConditionType = "Finished"
+)
+
+// MXJobCondition describes the state of the mxjob at a certain point.
+type MXJobCondition struct {
+	// Type of mxjob condition.
+	Type MXJobConditionType `json:"type"`
+	// Status of the condition, one of True, False, Unknown.
+	Status v1.ConditionStatus `json:"status"`
+	// The reason for the condition's last transition.
+	Reason string `json:"reason,omitempty"`
+	// A human readable message indicating details about the transition.
+	Message string `json:"message,omitempty"`
+	// The last time this condition was updated.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
+	// Last time the condition transitioned from one status to another.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// MXReplicaStatus represents the current observed state of the MXReplica.
+type MXReplicaStatus struct {
+	// The number of actively running pods.
+	Active int32 `json:"active,omitempty"`
+
+	// The number of pods which reached phase Succeeded.
+	Succeeded int32 `json:"succeeded,omitempty"`
+
+	// The number of pods which reached phase Failed.
+	Failed int32 `json:"failed,omitempty"`
+}
+
+// MXJobStatus represents the current observed state of the MXJob.
+type MXJobStatus struct {
+	// Conditions is an array of current observed mxjob conditions.
+	Conditions []MXJobCondition `json:"conditions"`
+
+	// MXReplicaStatuses is map of MXReplicaType and MXReplicaStatus,
+	// specifies the status of each MXReplica.
+	MXReplicaStatuses map[MXReplicaType]*MXReplicaStatus `json:"mxReplicaStatuses"`
+
+	// Represents time when the mxjob was acknowledged by the mxjob controller.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Represents time when the mxjob was completed. It is not guaranteed to
+	// be set in happens-before order across separate operations.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Represents last time when the mxjob was reconciled. It is not guaranteed to
+	// be set in happens-before order across separate operations.
+	LastReconcileTime *metav1.Time `json:"lastReconcileTime,omitempty"`
+}
+
+// MXReplicaSpec is a description of the MXReplica.
+type MXReplicaSpec struct {
+	// Replicas is the desired number of replicas of the given template.
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Template is the object that describes the pod that will be created for this MXReplica.
+	Template v1.PodTemplateSpec `json:"template,omitempty"`
+
+	// Restart policy for all replicas within the MXJob.
+	RestartPolicy RestartPolicy `json:"restartPolicy,omitempty"`
+}
+
+// RestartPolicy describes how the replicas should be restarted.
+type RestartPolicy string
+
+const (
+	RestartPolicyAlways    RestartPolicy = "Always"
+	RestartPolicyOnFailure RestartPolicy = "OnFailure"
+	RestartPolicyNever     RestartPolicy = "Never"
+)
+
+// MXJobSpec is a description of the desired state of the MXJob.
+type MXJobSpec struct {
+	// ActiveDeadlineSeconds is the duration in seconds, relative to StartTime,
+	// that the job may stay active.
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+
+	// Number of retries before marking this job as failed.
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+
+	// MXReplicaSpecs is map of MXReplicaType and MXReplicaSpec,
+	// specifies the MXReplica to run.
+	MXReplicaSpecs map[MXReplicaType]*MXReplicaSpec `json:"mxReplicaSpecs"`
+
+	// JobMode specifies the running mode of the MXJob.
+	JobMode JobModeType `json:"jobMode,omitempty"`
+
+	// Suspend specifies whether the MXJob controller should suspend the running
+	// of the job, e.g. for admission by a queueing system such as Kueue. Defaults
+	// to false. While suspended, the controller deletes all active pods and
+	// services for the job and does not create new ones until it is resumed.
+	Suspend *bool `json:"suspend,omitempty"`
+
+	// TTLSecondsAfterFinished is the number of seconds to retain a finished
+	// (Succeeded or Failed) MXJob before it is automatically deleted, along with
+	// its pods and services. If this field is nil, the MXJob is not cleaned up
+	// automatically.
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+}
+
+// JobModeType specifies the running mode of the MXJob, e.g. MXTrain or MXTune.
+type JobModeType string
+
+const (
+	MXTrain JobModeType = "MXTrain"
+	MXTune  JobModeType = "MXTune"
+)
+
+// MXJob represents the configuration of a single MXJob.
+type MXJob struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the desired state of the MXJob.
+	Spec MXJobSpec `json:"spec,omitempty"`
+
+	// Status is the current observed state of the MXJob.
+	Status MXJobStatus `json:"status,omitempty"`
+}
+
+// Finished returns the MXJobFinished condition and true if the MXJob has
+// reached a terminal state (Succeeded or Failed) and that state has been
+// recorded. It is safe to call repeatedly and never mutates the job: once an
+// MXJob is finished this keeps returning the same condition, so reconcilers
+// and external integrations (e.g. Kueue's jobframework) can use it to drive
+// finalizer removal and pod cleanup without racing with the controller's own
+// status updates.
+func (job *MXJob) Finished() (MXJobCondition, bool) {
+	for _, c := range job.Status.Conditions {
+		if c.Type == MXJobFinished && c.Status == v1.ConditionTrue {
+			return c, true
+		}
+	}
+	return MXJobCondition{}, false
+}
@@ -0,0 +1,29 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mxnet
+
+import (
+	mxv1 "github.com/kubeflow/mxnet-operator/pkg/apis/mxnet/v1"
+)
+
+// ContainLauncherSpec returns true if the MXJob's replica specs include a
+// Launcher replica, i.e. it is a single-controller job (e.g. Horovod-on-MXNet
+// via MPI) whose completion is driven solely by the launcher pod.
+func ContainLauncherSpec(mxjob *mxv1.MXJob) bool {
+	if _, ok := mxjob.Spec.MXReplicaSpecs[mxv1.MXReplicaTypeLauncher]; ok {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mxnet
+
+import (
+	"context"
+
+	"k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	mxv1 "github.com/kubeflow/mxnet-operator/pkg/apis/mxnet/v1"
+)
+
+// Reconcile implements the controller-runtime reconciliation loop for MXJob.
+// It replaces the hand-written informer/workqueue sync handler: each call is
+// scoped to a single MXJob identified by req and fetches it via tc.Client
+// (after SetupWithManager, the manager's cache-backed client). Any delayed
+// re-sync (e.g. ActiveDeadlineSeconds, TTLSecondsAfterFinished) is requested
+// via the returned ctrl.Result.RequeueAfter; nothing is enqueued onto a
+// workqueue of its own.
+func (tc *MXController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithName("mxjob-controller").WithValues("mxjob", req.NamespacedName)
+	ctx = log.IntoContext(ctx, logger)
+
+	mxjob := &mxv1.MXJob{}
+	if err := tc.Client.Get(ctx, req.NamespacedName, mxjob); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("mxjob has been deleted")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "unable to fetch mxjob")
+		return ctrl.Result{}, err
+	}
+
+	// While the job is suspended, delete everything it owns and skip pod
+	// creation entirely: syncMXJob is never reached, so nothing gets recreated
+	// until Spec.Suspend is cleared.
+	if mxjob.Spec.Suspend != nil && *mxjob.Spec.Suspend {
+		if err := tc.deleteActivePodsAndServices(ctx, mxjob); err != nil {
+			logger.Error(err, "failed to delete active pods/services for suspended mxjob")
+			return ctrl.Result{}, err
+		}
+		requeueAfter, err := tc.updateStatusSingle(ctx, mxjob, "", 0, false, false)
+		if err != nil {
+			logger.Error(err, "failed to update suspended mxjob status")
+			return ctrl.Result{}, err
+		}
+		if err := tc.updateMXJobStatus(ctx, mxjob); err != nil {
+			logger.Error(err, "failed to persist suspended mxjob status")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	result, err := tc.syncMXJob(ctx, mxjob)
+	if err != nil {
+		logger.Error(err, "failed to sync mxjob")
+		return ctrl.Result{}, err
+	}
+
+	return result, nil
+}
+
+// SetupWithManager registers the MXJob reconciler with mgr.
+func (tc *MXController) SetupWithManager(mgr ctrl.Manager) error {
+	tc.Client = mgr.GetClient()
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&mxv1.MXJob{}).
+		Owns(&v1.Pod{}).
+		Owns(&v1.Service{}).
+		Complete(tc)
+}
@@ -16,15 +16,16 @@
 package mxnet
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	mxv1 "github.com/kubeflow/mxnet-operator/pkg/apis/mxnet/v1"
-	mxlogger "github.com/kubeflow/tf-operator/pkg/logger"
+	"github.com/kubeflow/mxnet-operator/pkg/metrics"
 )
 
 const (
@@ -38,14 +39,49 @@ const (
 	mxJobFailedReason = "MXJobFailed"
 	// mxJobRestarting is added in a mxjob when it is restarting.
 	mxJobRestartingReason = "MXJobRestarting"
+	// mxJobSuspendedReason is added in a mxjob when it is suspended.
+	mxJobSuspendedReason = "MXJobSuspended"
+	// mxJobResumedReason is added in a mxjob when it is resumed from suspension.
+	mxJobResumedReason = "MXJobResumed"
+	// mxJobFinishedReason is added in a mxjob the first time it reaches a terminal state.
+	mxJobFinishedReason = "MXJobFinished"
 )
 
-// updateStatus updates the status of the mxjob.
-func (tc *MXController) updateStatusSingle(mxjob *mxv1.MXJob, rtype mxv1.MXReplicaType, replicas int, restart, schedulerCompleted bool) error {
-	mxjobKey, err := KeyFunc(mxjob)
-	if err != nil {
-		utilruntime.HandleError(fmt.Errorf("couldn't get key for mxjob object %#v: %v", mxjob, err))
-		return err
+// updateStatusSingle updates the status of the mxjob. The returned duration, when
+// non-zero, is how long the caller should wait before the next reconcile
+// (e.g. to delete the job once its TTLSecondsAfterFinished elapses); it is
+// surfaced by Reconcile as ctrl.Result.RequeueAfter.
+func (tc *MXController) updateStatusSingle(ctx context.Context, mxjob *mxv1.MXJob, rtype mxv1.MXReplicaType, replicas int, restart, schedulerCompleted bool) (time.Duration, error) {
+	logger := log.FromContext(ctx).WithName("mxjob-controller")
+
+	// If the job is suspended, the reconciler has already deleted (or is about
+	// to delete) its active pods/services; mark it as such, explicitly flip
+	// Running to False, and skip the rest of the status computation since
+	// there is nothing meaningful left to report per-replica.
+	if mxjob.Spec.Suspend != nil && *mxjob.Spec.Suspend {
+		if hasCondition(mxjob.Status, mxv1.MXJobSuspended) {
+			return 0, nil
+		}
+		msg := fmt.Sprintf("MXJob %s is suspended.", mxjob.Name)
+		if err := updateMXJobConditionsStatus(mxjob, mxv1.MXJobRunning, v1.ConditionFalse, mxJobSuspendedReason, msg); err != nil {
+			logger.Error(err, "failed to append mxjob condition")
+			return 0, err
+		}
+		if err := tc.recordCondition(mxjob, mxv1.MXJobSuspended, mxJobSuspendedReason, msg); err != nil {
+			logger.Error(err, "failed to append mxjob condition")
+			return 0, err
+		}
+		return 0, nil
+	}
+
+	// The job was suspended and has just been resumed: clear the Suspended
+	// condition so that Running/Succeeded/Failed can be set normally below.
+	if hasCondition(mxjob.Status, mxv1.MXJobSuspended) {
+		msg := fmt.Sprintf("MXJob %s is resumed.", mxjob.Name)
+		if err := tc.recordCondition(mxjob, mxv1.MXJobRunning, mxJobResumedReason, msg); err != nil {
+			logger.Error(err, "failed to append mxjob condition")
+			return 0, err
+		}
 	}
 
 	// Expect to have `replicas - succeeded` pods alive.
@@ -53,27 +89,92 @@ func (tc *MXController) updateStatusSingle(mxjob *mxv1.MXJob, rtype mxv1.MXRepli
 	running := int(mxjob.Status.MXReplicaStatuses[rtype].Active)
 	failed := int(mxjob.Status.MXReplicaStatuses[rtype].Failed)
 
-	mxlogger.LoggerForJob(mxjob).Infof("MXJob=%s, ReplicaType=%s expected=%d, running=%d, failed=%d",
-		mxjob.Name, rtype, expected, running, failed)
+	logger.Info("computed mxjob replica status", "replicaType", rtype, "expected", expected, "running", running, "failed", failed)
 	// set StartTime.
 	if mxjob.Status.StartTime == nil {
 		now := metav1.Now()
 		mxjob.Status.StartTime = &now
-		// enqueue a sync to check if job past ActiveDeadlineSeconds
-		if mxjob.Spec.ActiveDeadlineSeconds != nil {
-			mxlogger.LoggerForJob(mxjob).Infof("Job with ActiveDeadlineSeconds will sync after %d seconds", *mxjob.Spec.ActiveDeadlineSeconds)
-			tc.WorkQueue.AddAfter(mxjobKey, time.Duration(*mxjob.Spec.ActiveDeadlineSeconds)*time.Second)
+		metrics.CreatedJobsCounterInc(mxjob.Namespace)
+	}
+
+	// If the job hasn't finished yet and has an ActiveDeadlineSeconds, requeue
+	// the reconcile for when the deadline will be reached so that a job stuck
+	// without further pod events still gets checked for timeout.
+	var requeueAfter time.Duration
+	if mxjob.Status.CompletionTime == nil && mxjob.Spec.ActiveDeadlineSeconds != nil {
+		deadline := time.Duration(*mxjob.Spec.ActiveDeadlineSeconds) * time.Second
+		remaining := mxjob.Status.StartTime.Add(deadline).Sub(time.Now())
+		if remaining < 0 {
+			remaining = 0
+		}
+		logger.Info("job with ActiveDeadlineSeconds will sync later", "activeDeadlineSeconds", *mxjob.Spec.ActiveDeadlineSeconds)
+		requeueAfter = remaining
+	}
+
+	if !ContainSchedulerSpec(mxjob) && ContainLauncherSpec(mxjob) {
+		// Single-controller jobs (e.g. Horovod-on-MXNet via MPI) use the launcher
+		// pod as the sole source of truth for completion: workers may be killed
+		// by the launcher once it exits, so their exit codes must be ignored.
+		if rtype == mxv1.MXReplicaTypeLauncher {
+			if running > 0 {
+				msg := fmt.Sprintf("MXJob %s is running.", mxjob.Name)
+				err := tc.recordCondition(mxjob, mxv1.MXJobRunning, mxJobRunningReason, msg)
+				if err != nil {
+					logger.Error(err, "failed to append mxjob condition")
+					return 0, err
+				}
+			}
+			// A single launcher pod can't be both failed and succeeded, but check
+			// failed first and else-chain so that precedence is explicit rather
+			// than relying on setCondition's terminal guard to pick a winner.
+			if failed > 0 {
+				msg := fmt.Sprintf("MXJob %s is failed.", mxjob.Name)
+				if mxjob.Status.CompletionTime == nil {
+					now := metav1.Now()
+					mxjob.Status.CompletionTime = &now
+				}
+				err := tc.recordCondition(mxjob, mxv1.MXJobFailed, mxJobFailedReason, msg)
+				if err != nil {
+					logger.Error(err, "failed to append mxjob condition")
+					return 0, err
+				}
+			} else if expected == 0 {
+				msg := fmt.Sprintf("MXJob %s is successfully completed.", mxjob.Name)
+				if mxjob.Status.CompletionTime == nil {
+					now := metav1.Now()
+					mxjob.Status.CompletionTime = &now
+				}
+				err := tc.recordCondition(mxjob, mxv1.MXJobSucceeded, mxJobSucceededReason, msg)
+				if err != nil {
+					logger.Error(err, "failed to append mxjob condition")
+					return 0, err
+				}
+			}
+		}
+		if mxjob.Status.CompletionTime != nil {
+			msg := fmt.Sprintf("MXJob %s is finished.", mxjob.Name)
+			if err := tc.recordCondition(mxjob, mxv1.MXJobFinished, mxJobFinishedReason, msg); err != nil {
+				logger.Error(err, "failed to append mxjob condition")
+				return 0, err
+			}
+			requeueAfter, err := tc.cleanupMXJob(ctx, mxjob)
+			if err != nil {
+				logger.Error(err, "failed to clean up mxjob")
+				return 0, err
+			}
+			return requeueAfter, nil
 		}
+		return requeueAfter, nil
 	}
 
 	if ContainSchedulerSpec(mxjob) {
 		if rtype == mxv1.MXReplicaTypeScheduler {
 			if running > 0 {
 				msg := fmt.Sprintf("MXJob %s is running.", mxjob.Name)
-				err := updateMXJobConditions(mxjob, mxv1.MXJobRunning, mxJobRunningReason, msg)
+				err := tc.recordCondition(mxjob, mxv1.MXJobRunning, mxJobRunningReason, msg)
 				if err != nil {
-					mxlogger.LoggerForJob(mxjob).Infof("Append mxjob condition error: %v", err)
-					return err
+					logger.Error(err, "failed to append mxjob condition")
+					return 0, err
 				}
 			}
 			if expected == 0 {
@@ -82,10 +183,10 @@ func (tc *MXController) updateStatusSingle(mxjob *mxv1.MXJob, rtype mxv1.MXRepli
 					now := metav1.Now()
 					mxjob.Status.CompletionTime = &now
 				}
-				err := updateMXJobConditions(mxjob, mxv1.MXJobSucceeded, mxJobSucceededReason, msg)
+				err := tc.recordCondition(mxjob, mxv1.MXJobSucceeded, mxJobSucceededReason, msg)
 				if err != nil {
-					mxlogger.LoggerForJob(mxjob).Infof("Append mxjob condition error: %v", err)
-					return err
+					logger.Error(err, "failed to append mxjob condition")
+					return 0, err
 				}
 			}
 		}
@@ -98,18 +199,18 @@ func (tc *MXController) updateStatusSingle(mxjob *mxv1.MXJob, rtype mxv1.MXRepli
 					now := metav1.Now()
 					mxjob.Status.CompletionTime = &now
 				}
-				err := updateMXJobConditions(mxjob, mxv1.MXJobSucceeded, mxJobSucceededReason, msg)
+				err := tc.recordCondition(mxjob, mxv1.MXJobSucceeded, mxJobSucceededReason, msg)
 				if err != nil {
-					mxlogger.LoggerForJob(mxjob).Infof("Append mxjob condition error: %v", err)
-					return err
+					logger.Error(err, "failed to append mxjob condition")
+					return 0, err
 				}
 			} else if running > 0 {
 				// Some workers are still running, leave a running condition.
 				msg := fmt.Sprintf("MXJob %s is running.", mxjob.Name)
-				err := updateMXJobConditions(mxjob, mxv1.MXJobRunning, mxJobRunningReason, msg)
+				err := tc.recordCondition(mxjob, mxv1.MXJobRunning, mxJobRunningReason, msg)
 				if err != nil {
-					mxlogger.LoggerForJob(mxjob).Infof("Append mxjob condition error: %v", err)
-					return err
+					logger.Error(err, "failed to append mxjob condition")
+					return 0, err
 				}
 			}
 		}
@@ -118,10 +219,10 @@ func (tc *MXController) updateStatusSingle(mxjob *mxv1.MXJob, rtype mxv1.MXRepli
 	if failed > 0 {
 		if restart {
 			msg := fmt.Sprintf("MXJob %s is restarting.", mxjob.Name)
-			err := updateMXJobConditions(mxjob, mxv1.MXJobRestarting, mxJobRestartingReason, msg)
+			err := tc.recordCondition(mxjob, mxv1.MXJobRestarting, mxJobRestartingReason, msg)
 			if err != nil {
-				mxlogger.LoggerForJob(mxjob).Infof("Append mxjob condition error: %v", err)
-				return err
+				logger.Error(err, "failed to append mxjob condition")
+				return 0, err
 			}
 		} else {
 			msg := fmt.Sprintf("MXJob %s is failed.", mxjob.Name)
@@ -129,20 +230,33 @@ func (tc *MXController) updateStatusSingle(mxjob *mxv1.MXJob, rtype mxv1.MXRepli
 				now := metav1.Now()
 				mxjob.Status.CompletionTime = &now
 			}
-			err := updateMXJobConditions(mxjob, mxv1.MXJobFailed, mxJobFailedReason, msg)
+			err := tc.recordCondition(mxjob, mxv1.MXJobFailed, mxJobFailedReason, msg)
 			if err != nil {
-				mxlogger.LoggerForJob(mxjob).Infof("Append mxjob condition error: %v", err)
-				return err
+				logger.Error(err, "failed to append mxjob condition")
+				return 0, err
 			}
 		}
 	}
-	return nil
+
+	if mxjob.Status.CompletionTime != nil {
+		msg := fmt.Sprintf("MXJob %s is finished.", mxjob.Name)
+		if err := tc.recordCondition(mxjob, mxv1.MXJobFinished, mxJobFinishedReason, msg); err != nil {
+			logger.Error(err, "failed to append mxjob condition")
+			return 0, err
+		}
+		requeueAfter, err := tc.cleanupMXJob(ctx, mxjob)
+		if err != nil {
+			logger.Error(err, "failed to clean up mxjob")
+			return 0, err
+		}
+		return requeueAfter, nil
+	}
+	return requeueAfter, nil
 }
 
 // updateMXJobStatus updates the status of the given MXJob.
-func (tc *MXController) updateMXJobStatus(mxjob *mxv1.MXJob) error {
-	_, err := tc.mxJobClientSet.KubeflowV1().MXJobs(mxjob.Namespace).UpdateStatus(mxjob)
-	return err
+func (tc *MXController) updateMXJobStatus(ctx context.Context, mxjob *mxv1.MXJob) error {
+	return tc.Client.Status().Update(ctx, mxjob)
 }
 
 // updateMXJobConditions updates the conditions of the given mxjob.
@@ -152,6 +266,49 @@ func updateMXJobConditions(mxjob *mxv1.MXJob, conditionType mxv1.MXJobConditionT
 	return nil
 }
 
+// updateMXJobConditionsStatus updates the conditions of the given mxjob with
+// an explicit status, for the rare cases (e.g. flipping Running to False on
+// suspend) where the condition being recorded isn't simply "now true".
+func updateMXJobConditionsStatus(mxjob *mxv1.MXJob, conditionType mxv1.MXJobConditionType, status v1.ConditionStatus, reason, message string) error {
+	condition := newConditionWithStatus(conditionType, status, reason, message)
+	setCondition(&mxjob.Status, condition)
+	return nil
+}
+
+// recordCondition sets the given condition on mxjob and, the first time the
+// condition is observed, emits a Kubernetes Event and the matching Prometheus
+// metric so that terminal transitions are observable outside of the MXJob
+// status itself.
+func (tc *MXController) recordCondition(mxjob *mxv1.MXJob, conditionType mxv1.MXJobConditionType, reason, message string) error {
+	alreadySet := hasCondition(mxjob.Status, conditionType)
+
+	if err := updateMXJobConditions(mxjob, conditionType, reason, message); err != nil {
+		return err
+	}
+
+	// Nothing to report if the condition was already set, or if setCondition
+	// refused it (e.g. because the job is already terminal).
+	if alreadySet || !hasCondition(mxjob.Status, conditionType) {
+		return nil
+	}
+
+	if tc.Recorder != nil {
+		tc.Recorder.Event(mxjob, v1.EventTypeNormal, reason, message)
+	}
+
+	switch conditionType {
+	case mxv1.MXJobSucceeded:
+		metrics.SucceededJobsCounterInc(mxjob.Namespace)
+		metrics.JobsDurationSecondsObserve(mxjob.Namespace, mxjob.Status.StartTime, mxjob.Status.CompletionTime)
+	case mxv1.MXJobFailed:
+		metrics.FailedJobsCounterInc(mxjob.Namespace)
+		metrics.JobsDurationSecondsObserve(mxjob.Namespace, mxjob.Status.StartTime, mxjob.Status.CompletionTime)
+	case mxv1.MXJobRestarting:
+		metrics.RestartedJobsCounterInc(mxjob.Namespace)
+	}
+	return nil
+}
+
 // initializeMXReplicaStatuses initializes the MXReplicaStatuses for replica.
 func initializeMXReplicaStatuses(mxjob *mxv1.MXJob, rtype mxv1.MXReplicaType) {
 	if mxjob.Status.MXReplicaStatuses == nil {
@@ -175,9 +332,14 @@ func updateMXJobReplicaStatuses(mxjob *mxv1.MXJob, rtype mxv1.MXReplicaType, pod
 
 // newCondition creates a new mxjob condition.
 func newCondition(conditionType mxv1.MXJobConditionType, reason, message string) mxv1.MXJobCondition {
+	return newConditionWithStatus(conditionType, v1.ConditionTrue, reason, message)
+}
+
+// newConditionWithStatus creates a new mxjob condition with an explicit status.
+func newConditionWithStatus(conditionType mxv1.MXJobConditionType, status v1.ConditionStatus, reason, message string) mxv1.MXJobCondition {
 	return mxv1.MXJobCondition{
 		Type:               conditionType,
-		Status:             v1.ConditionTrue,
+		Status:             status,
 		LastUpdateTime:     metav1.Now(),
 		LastTransitionTime: metav1.Now(),
 		Reason:             reason,
@@ -214,8 +376,15 @@ func isFailed(status mxv1.MXJobStatus) bool {
 // If the condition that we are about to add already exists
 // and has the same status and reason then we are not going to update.
 func setCondition(status *mxv1.MXJobStatus, condition mxv1.MXJobCondition) {
-	// Do nothing if MXJobStatus is completed
+	// Once the job is terminal, no condition may reopen or mutate it, with one
+	// exception: the MXJobFinished bookkeeping condition, which is still
+	// allowed through so that finalizer-driven cleanup has something to watch
+	// for. It is itself only ever written once.
 	if isFailed(*status) || isSucceeded(*status) {
+		if condition.Type != mxv1.MXJobFinished || hasCondition(*status, mxv1.MXJobFinished) {
+			return
+		}
+		status.Conditions = append(status.Conditions, condition)
 		return
 	}
 
@@ -246,13 +415,16 @@ func filterOutCondition(conditions []mxv1.MXJobCondition, condType mxv1.MXJobCon
 		if condType == mxv1.MXJobRunning && c.Type == mxv1.MXJobRestarting {
 			continue
 		}
+		if condType == mxv1.MXJobRunning && c.Type == mxv1.MXJobSuspended {
+			continue
+		}
 
 		if c.Type == condType {
 			continue
 		}
 
-		// Set the running condition status to be false when current condition failed or succeeded
-		if (condType == mxv1.MXJobFailed || condType == mxv1.MXJobSucceeded) && c.Type == mxv1.MXJobRunning {
+		// Set the running condition status to be false when current condition failed, succeeded or suspended.
+		if (condType == mxv1.MXJobFailed || condType == mxv1.MXJobSucceeded || condType == mxv1.MXJobSuspended) && c.Type == mxv1.MXJobRunning {
 			c.Status = v1.ConditionFalse
 		}
 
@@ -0,0 +1,179 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mxnet
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mxv1 "github.com/kubeflow/mxnet-operator/pkg/apis/mxnet/v1"
+)
+
+// stubClient is a minimal client.Client double: every method not overridden
+// panics if called, which is fine since each test only exercises the path(s)
+// it overrides.
+type stubClient struct {
+	client.Client
+	deletedJobs int
+}
+
+func (s *stubClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if _, ok := obj.(*mxv1.MXJob); ok {
+		s.deletedJobs++
+	}
+	return nil
+}
+
+func (s *stubClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return nil
+}
+
+func newTestController() (*MXController, *stubClient) {
+	sc := &stubClient{}
+	return &MXController{Client: sc, Recorder: record.NewFakeRecorder(10)}, sc
+}
+
+// conditionOfType returns the condition of the given type in status, or nil.
+// getCondition is not used here because it returns the last condition
+// whatever its type.
+func conditionOfType(status mxv1.MXJobStatus, condType mxv1.MXJobConditionType) *mxv1.MXJobCondition {
+	for i := range status.Conditions {
+		if status.Conditions[i].Type == condType {
+			return &status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+func TestUpdateStatusSingle_SuspendResume(t *testing.T) {
+	tc, _ := newTestController()
+	suspend := true
+	mxjob := &mxv1.MXJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-job", Namespace: "default"},
+		Spec:       mxv1.MXJobSpec{Suspend: &suspend},
+		Status: mxv1.MXJobStatus{
+			MXReplicaStatuses: map[mxv1.MXReplicaType]*mxv1.MXReplicaStatus{},
+		},
+	}
+
+	if _, err := tc.updateStatusSingle(context.TODO(), mxjob, "", 0, false, false); err != nil {
+		t.Fatalf("updateStatusSingle() suspend error = %v", err)
+	}
+	if !hasCondition(mxjob.Status, mxv1.MXJobSuspended) {
+		t.Fatal("expected MXJobSuspended condition after suspending")
+	}
+	running := conditionOfType(mxjob.Status, mxv1.MXJobRunning)
+	if running == nil || running.Status != v1.ConditionFalse {
+		t.Fatalf("expected Running=False on suspend, got %+v", running)
+	}
+
+	// Resuming should clear Suspended and flip Running back to True.
+	mxjob.Spec.Suspend = nil
+	mxjob.Status.MXReplicaStatuses[mxv1.MXReplicaTypeWorker] = &mxv1.MXReplicaStatus{Active: 1}
+	if _, err := tc.updateStatusSingle(context.TODO(), mxjob, mxv1.MXReplicaTypeWorker, 1, false, false); err != nil {
+		t.Fatalf("updateStatusSingle() resume error = %v", err)
+	}
+	if hasCondition(mxjob.Status, mxv1.MXJobSuspended) {
+		t.Fatal("expected MXJobSuspended condition to be cleared after resume")
+	}
+	running = conditionOfType(mxjob.Status, mxv1.MXJobRunning)
+	if running == nil || running.Status != v1.ConditionTrue {
+		t.Fatalf("expected Running=True after resume, got %+v", running)
+	}
+}
+
+func TestCleanupMXJob_TTLRequeueThenDelete(t *testing.T) {
+	tc, sc := newTestController()
+	ttl := int32(60)
+	completedAt := metav1.NewTime(time.Now().Add(-30 * time.Second))
+	mxjob := &mxv1.MXJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-job", Namespace: "default"},
+		Spec:       mxv1.MXJobSpec{TTLSecondsAfterFinished: &ttl},
+		Status:     mxv1.MXJobStatus{CompletionTime: &completedAt},
+	}
+
+	requeueAfter, err := tc.cleanupMXJob(context.TODO(), mxjob)
+	if err != nil {
+		t.Fatalf("cleanupMXJob() error = %v", err)
+	}
+	if requeueAfter <= 0 {
+		t.Fatalf("expected a positive requeueAfter before the TTL elapses, got %v", requeueAfter)
+	}
+	if sc.deletedJobs != 0 {
+		t.Fatalf("expected no deletion before the TTL elapses, got %d deletes", sc.deletedJobs)
+	}
+
+	mxjob.Status.CompletionTime = &metav1.Time{Time: time.Now().Add(-2 * time.Minute)}
+	requeueAfter, err = tc.cleanupMXJob(context.TODO(), mxjob)
+	if err != nil {
+		t.Fatalf("cleanupMXJob() error = %v", err)
+	}
+	if requeueAfter != 0 {
+		t.Fatalf("expected no further requeue once the TTL elapsed, got %v", requeueAfter)
+	}
+	if sc.deletedJobs != 1 {
+		t.Fatalf("expected the mxjob to be deleted once the TTL elapsed, got %d deletes", sc.deletedJobs)
+	}
+}
+
+func TestUpdateStatusSingle_LauncherCompletion(t *testing.T) {
+	cases := []struct {
+		name          string
+		failed        int32
+		succeeded     int32
+		wantSucceeded bool
+		wantFailed    bool
+	}{
+		{name: "succeeded", succeeded: 1, wantSucceeded: true},
+		{name: "failed takes precedence over succeeded", failed: 1, succeeded: 1, wantFailed: true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			tc, _ := newTestController()
+			mxjob := &mxv1.MXJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-job", Namespace: "default"},
+				Spec: mxv1.MXJobSpec{
+					MXReplicaSpecs: map[mxv1.MXReplicaType]*mxv1.MXReplicaSpec{
+						mxv1.MXReplicaTypeLauncher: {},
+						mxv1.MXReplicaTypeWorker:   {},
+					},
+				},
+				Status: mxv1.MXJobStatus{
+					MXReplicaStatuses: map[mxv1.MXReplicaType]*mxv1.MXReplicaStatus{
+						mxv1.MXReplicaTypeLauncher: {Succeeded: tt.succeeded, Failed: tt.failed},
+					},
+				},
+			}
+
+			if _, err := tc.updateStatusSingle(context.TODO(), mxjob, mxv1.MXReplicaTypeLauncher, 1, false, false); err != nil {
+				t.Fatalf("updateStatusSingle() error = %v", err)
+			}
+
+			if got := hasCondition(mxjob.Status, mxv1.MXJobSucceeded); got != tt.wantSucceeded {
+				t.Errorf("MXJobSucceeded = %v, want %v", got, tt.wantSucceeded)
+			}
+			if got := hasCondition(mxjob.Status, mxv1.MXJobFailed); got != tt.wantFailed {
+				t.Errorf("MXJobFailed = %v, want %v", got, tt.wantFailed)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mxnet
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mxv1 "github.com/kubeflow/mxnet-operator/pkg/apis/mxnet/v1"
+)
+
+// deleteActivePodsAndServices deletes all pods and services owned by mxjob.
+// It is called when an MXJob transitions to Spec.Suspend=true so that the
+// reconciler can skip pod creation entirely until the job is resumed.
+func (tc *MXController) deleteActivePodsAndServices(ctx context.Context, mxjob *mxv1.MXJob) error {
+	listOpts := []client.ListOption{
+		client.InNamespace(mxjob.Namespace),
+		client.MatchingLabels{mxv1.MXJobNameLabel: mxjob.Name},
+	}
+
+	pods := &v1.PodList{}
+	if err := tc.Client.List(ctx, pods, listOpts...); err != nil {
+		return fmt.Errorf("couldn't list pods for mxjob %s/%s: %v", mxjob.Namespace, mxjob.Name, err)
+	}
+	for i := range pods.Items {
+		if err := tc.Client.Delete(ctx, &pods.Items[i]); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("couldn't delete pod %s: %v", pods.Items[i].Name, err)
+		}
+	}
+
+	services := &v1.ServiceList{}
+	if err := tc.Client.List(ctx, services, listOpts...); err != nil {
+		return fmt.Errorf("couldn't list services for mxjob %s/%s: %v", mxjob.Namespace, mxjob.Name, err)
+	}
+	for i := range services.Items {
+		if err := tc.Client.Delete(ctx, &services.Items[i]); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("couldn't delete service %s: %v", services.Items[i].Name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mxnet
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	mxv1 "github.com/kubeflow/mxnet-operator/pkg/apis/mxnet/v1"
+)
+
+// cleanupMXJob deletes a finished MXJob once its TTLSecondsAfterFinished has
+// elapsed. If the job hasn't finished or has no TTL configured, it returns 0
+// and does nothing. If the TTL hasn't elapsed yet, it returns the remaining
+// duration so that the caller can requeue the reconcile for when the TTL
+// will expire; Reconcile surfaces this as ctrl.Result.RequeueAfter.
+func (tc *MXController) cleanupMXJob(ctx context.Context, mxjob *mxv1.MXJob) (time.Duration, error) {
+	logger := log.FromContext(ctx).WithName("mxjob-controller")
+
+	if mxjob.Spec.TTLSecondsAfterFinished == nil {
+		return 0, nil
+	}
+
+	if mxjob.Status.CompletionTime == nil {
+		return 0, nil
+	}
+
+	duration := time.Duration(*mxjob.Spec.TTLSecondsAfterFinished) * time.Second
+	remaining := mxjob.Status.CompletionTime.Add(duration).Sub(time.Now())
+	// Clamp to zero: clock skew or a sync that runs late should not push the
+	// job further into the future, it should just delete it now.
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	if remaining > 0 {
+		logger.Info("mxjob will be cleaned up later", "remaining", remaining)
+		return remaining, nil
+	}
+
+	logger.Info("cleaning up mxjob after TTLSecondsAfterFinished")
+	if err := tc.deleteMXJob(ctx, mxjob); err != nil {
+		utilruntime.HandleError(fmt.Errorf("couldn't delete mxjob %s/%s: %v", mxjob.Namespace, mxjob.Name, err))
+		return 0, err
+	}
+	return 0, nil
+}
+
+// deleteMXJob deletes mxjob's pods and services and then the MXJob itself.
+// The pods/services are deleted explicitly first (mirroring
+// deleteActivePodsAndServices) since they're only owned by the MXJob via
+// owner references, which the Background propagation policy below also
+// cascades through for anything left behind.
+func (tc *MXController) deleteMXJob(ctx context.Context, mxjob *mxv1.MXJob) error {
+	if err := tc.deleteActivePodsAndServices(ctx, mxjob); err != nil {
+		return err
+	}
+
+	propagationPolicy := metav1.DeletePropagationBackground
+	if err := tc.Client.Delete(ctx, mxjob, &client.DeleteOptions{PropagationPolicy: &propagationPolicy}); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("couldn't delete mxjob %s/%s: %v", mxjob.Namespace, mxjob.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics exposes Prometheus instrumentation for the lifecycle of
+// MXJobs, mirroring the CreatedJobsCounterInc-style counters of the upstream
+// training-operator.
+package metrics
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// JobsCreatedCount counts the total number of MXJobs created, partitioned by namespace.
+	JobsCreatedCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "mxjob_created_total",
+			Help: "Counts number of MXJob created",
+		},
+		[]string{"namespace"},
+	)
+
+	// JobsSucceededCount counts the total number of MXJobs that succeeded, partitioned by namespace.
+	JobsSucceededCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "mxjob_succeeded_total",
+			Help: "Counts number of MXJob succeeded",
+		},
+		[]string{"namespace"},
+	)
+
+	// JobsFailedCount counts the total number of MXJobs that failed, partitioned by namespace.
+	JobsFailedCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "mxjob_failed_total",
+			Help: "Counts number of MXJob failed",
+		},
+		[]string{"namespace"},
+	)
+
+	// JobsRestartedCount counts the total number of MXJobs that entered the Restarting state, partitioned by namespace.
+	JobsRestartedCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "mxjob_restarted_total",
+			Help: "Counts number of MXJob restarted",
+		},
+		[]string{"namespace"},
+	)
+
+	// JobsDurationSeconds observes how long an MXJob ran for, from StartTime to CompletionTime, partitioned by namespace.
+	JobsDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "mxjob_duration_seconds",
+			Help:    "Duration in seconds an MXJob ran for, from StartTime to CompletionTime",
+			Buckets: prometheus.ExponentialBuckets(1, 2, 15),
+		},
+		[]string{"namespace"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(JobsCreatedCount, JobsSucceededCount, JobsFailedCount, JobsRestartedCount, JobsDurationSeconds)
+}
+
+// CreatedJobsCounterInc increments the created jobs counter for namespace.
+func CreatedJobsCounterInc(namespace string) {
+	JobsCreatedCount.WithLabelValues(namespace).Inc()
+}
+
+// SucceededJobsCounterInc increments the succeeded jobs counter for namespace.
+func SucceededJobsCounterInc(namespace string) {
+	JobsSucceededCount.WithLabelValues(namespace).Inc()
+}
+
+// FailedJobsCounterInc increments the failed jobs counter for namespace.
+func FailedJobsCounterInc(namespace string) {
+	JobsFailedCount.WithLabelValues(namespace).Inc()
+}
+
+// RestartedJobsCounterInc increments the restarted jobs counter for namespace.
+func RestartedJobsCounterInc(namespace string) {
+	JobsRestartedCount.WithLabelValues(namespace).Inc()
+}
+
+// JobsDurationSecondsObserve records the time between startTime and completionTime for namespace.
+func JobsDurationSecondsObserve(namespace string, startTime, completionTime *v1.Time) {
+	if startTime == nil || completionTime == nil {
+		return
+	}
+	JobsDurationSeconds.WithLabelValues(namespace).Observe(completionTime.Sub(startTime.Time).Seconds())
+}